@@ -0,0 +1,73 @@
+package osc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// DefaultMaxBlobSize is the ceiling on a single OSC blob argument's
+	// length used by the zero-value Decoder.
+	DefaultMaxBlobSize int32 = 16 << 20 // 16 MiB
+
+	// DefaultMaxPacketSize is the ceiling on a single OSC packet's total
+	// length used by the zero-value Decoder.
+	DefaultMaxPacketSize int32 = 64 << 20 // 64 MiB
+)
+
+// ErrTruncated is returned when a packet ends, or a length header turns out
+// to be nonsensical, before all of its declared fields (a blob body, a padded
+// string, a bundle element) could be read.
+var ErrTruncated = errors.New("osc: truncated packet")
+
+// ErrOversizedPacket is returned when a blob or packet length header exceeds
+// the Decoder's configured maximum. This protects against a hostile or
+// corrupt length header on a stream transport, where (unlike UDP) there is no
+// datagram boundary to bound it.
+var ErrOversizedPacket = errors.New("osc: packet exceeds maximum size")
+
+// Decoder bounds how large a blob or packet a packet parse will accept. The
+// zero value uses DefaultMaxBlobSize and DefaultMaxPacketSize.
+type Decoder struct {
+	MaxBlobSize   int32
+	MaxPacketSize int32
+}
+
+// maxBlobSize returns d.MaxBlobSize, or DefaultMaxBlobSize if it is unset.
+func (d Decoder) maxBlobSize() int32 {
+	if d.MaxBlobSize > 0 {
+		return d.MaxBlobSize
+	}
+	return DefaultMaxBlobSize
+}
+
+// maxPacketSize returns d.MaxPacketSize, or DefaultMaxPacketSize if it is
+// unset.
+func (d Decoder) maxPacketSize() int32 {
+	if d.MaxPacketSize > 0 {
+		return d.MaxPacketSize
+	}
+	return DefaultMaxPacketSize
+}
+
+// ReadPacket parses a single OSC packet of exactly length bytes from r,
+// enforcing d's MaxPacketSize and, for any blob arguments, MaxBlobSize.
+func (d Decoder) ReadPacket(r io.Reader, length int) (Packet, error) {
+	if int32(length) > d.maxPacketSize() {
+		return nil, fmt.Errorf("osc: packet length %d exceeds maximum %d: %w", length, d.maxPacketSize(), ErrOversizedPacket)
+	}
+
+	reader, ok := r.(*bufio.Reader)
+	if !ok {
+		reader = bufio.NewReader(r)
+	}
+
+	start := 0
+	p, err := readPacket(reader, &start, length, d)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("osc: %v: %w", err, ErrTruncated)
+	}
+	return p, err
+}
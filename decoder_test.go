@@ -0,0 +1,46 @@
+package osc
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDecoderRejectsOversizedPacketLength(t *testing.T) {
+	dec := Decoder{MaxPacketSize: 4}
+
+	_, err := dec.ReadPacket(bytes.NewReader([]byte("/a\x00\x00")), 5)
+	if !errors.Is(err, ErrOversizedPacket) {
+		t.Fatalf("err = %v, want ErrOversizedPacket", err)
+	}
+}
+
+func TestDecoderRejectsOversizedBlob(t *testing.T) {
+	dec := Decoder{MaxBlobSize: 2}
+
+	msg := NewMessage("/blob", []byte{1, 2, 3, 4})
+	data, err := msg.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	_, err = dec.ReadPacket(bytes.NewReader(data), len(data))
+	if !errors.Is(err, ErrOversizedPacket) {
+		t.Fatalf("err = %v, want ErrOversizedPacket", err)
+	}
+}
+
+func TestDecoderRejectsTruncatedBlob(t *testing.T) {
+	msg := NewMessage("/blob", []byte{1, 2, 3, 4})
+	data, err := msg.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	truncated := data[:len(data)-2]
+
+	_, err = Decoder{}.ReadPacket(bytes.NewReader(truncated), len(truncated))
+	if !errors.Is(err, ErrTruncated) {
+		t.Fatalf("err = %v, want ErrTruncated", err)
+	}
+}
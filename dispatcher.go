@@ -0,0 +1,249 @@
+package osc
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Dispatcher routes an incoming OSC Packet (Message or Bundle) to the
+// Handlers registered for its address(es). A custom Dispatcher can be
+// supplied via WithDispatcher, for example to add logging, fan out to
+// multiple handlers, or bridge to another transport.
+type Dispatcher interface {
+	Dispatch(packet Packet, addr net.Addr)
+}
+
+// patternNode is one '/'-separated segment in a PatternDispatcher's address
+// tree. A node may carry a Handler, if some registered pattern ends at this
+// node, and any number of children, one per distinct next segment.
+type patternNode struct {
+	segment  string
+	handler  Handler
+	children []*patternNode
+}
+
+// child returns the child node for segment, creating it if it doesn't exist
+// yet.
+func (n *patternNode) child(segment string) *patternNode {
+	if c := n.findChild(segment); c != nil {
+		return c
+	}
+
+	c := &patternNode{segment: segment}
+	n.children = append(n.children, c)
+
+	return c
+}
+
+// findChild returns the child registered for the exact pattern segment, or
+// nil if there is none.
+func (n *patternNode) findChild(segment string) *patternNode {
+	for _, c := range n.children {
+		if c.segment == segment {
+			return c
+		}
+	}
+	return nil
+}
+
+// PatternDispatcher is the default Dispatcher. Registered address patterns
+// are stored as a tree keyed by '/'-separated segment; dispatch evaluates OSC
+// wildcards (*, ?, [...], {a,b}) at each node instead of compiling one
+// regular expression over the whole address per lookup.
+type PatternDispatcher struct {
+	root *patternNode
+}
+
+// NewPatternDispatcher returns an empty PatternDispatcher.
+func NewPatternDispatcher() *PatternDispatcher {
+	return &PatternDispatcher{root: &patternNode{}}
+}
+
+// AddMsgHandler registers handler for the OSC address pattern addr. addr may
+// contain the standard OSC wildcards. It returns an error if addr is not a
+// valid OSC address pattern.
+func (d *PatternDispatcher) AddMsgHandler(addr string, handler HandlerFunc) error {
+	if err := validateAddress(addr); err != nil {
+		return err
+	}
+
+	node := d.root
+	for _, seg := range strings.Split(strings.TrimPrefix(addr, "/"), "/") {
+		node = node.child(seg)
+	}
+	node.handler = handler
+
+	return nil
+}
+
+// RemoveMsgHandler removes the handler registered for the exact OSC address
+// pattern addr, if any is registered.
+func (d *PatternDispatcher) RemoveMsgHandler(addr string) {
+	node := d.root
+	for _, seg := range strings.Split(strings.TrimPrefix(addr, "/"), "/") {
+		next := node.findChild(seg)
+		if next == nil {
+			return
+		}
+		node = next
+	}
+	node.handler = nil
+}
+
+// Dispatch implements Dispatcher. A bare Message is matched directly; a
+// Bundle has every one of its Messages, and the Messages of every Bundle
+// nested within it, matched in turn.
+func (d *PatternDispatcher) Dispatch(packet Packet, addr net.Addr) {
+	switch p := packet.(type) {
+	case *Message:
+		d.dispatchMessage(p)
+
+	case *Bundle:
+		for _, msg := range p.Messages {
+			d.dispatchMessage(msg)
+		}
+		for _, nested := range p.Bundles {
+			d.Dispatch(nested, addr)
+		}
+	}
+}
+
+// dispatchMessage calls every Handler registered for a pattern that matches
+// msg.Address.
+func (d *PatternDispatcher) dispatchMessage(msg *Message) {
+	segments := strings.Split(strings.TrimPrefix(msg.Address, "/"), "/")
+	dispatchNode(d.root, segments, msg)
+}
+
+// dispatchNode descends the pattern tree alongside segments, branching into
+// every child whose pattern segment matches the next literal segment, and
+// invokes the Handler of every node reached once segments is exhausted.
+func dispatchNode(node *patternNode, segments []string, msg *Message) {
+	if len(segments) == 0 {
+		if node.handler != nil {
+			node.handler.HandleMessage(msg)
+		}
+		return
+	}
+
+	for _, c := range node.children {
+		if matchSegment(c.segment, segments[0]) {
+			dispatchNode(c, segments[1:], msg)
+		}
+	}
+}
+
+// matchSegment reports whether pattern, a single '/'-separated OSC address
+// pattern segment, matches the literal segment lit. It supports the standard
+// OSC wildcards: '?' (any one character), '*' (any run of characters),
+// '[...]' (a character class, with 'a-z' ranges and a leading '!' for
+// negation), and '{a,b,...}' (alternation between literal strings).
+func matchSegment(pattern, lit string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(lit); i++ {
+				if matchSegment(pattern[1:], lit[i:]) {
+					return true
+				}
+			}
+			return false
+
+		case '?':
+			if len(lit) == 0 {
+				return false
+			}
+			pattern, lit = pattern[1:], lit[1:]
+
+		case '[':
+			end := strings.IndexByte(pattern, ']')
+			if end < 0 || len(lit) == 0 || !matchClass(pattern[1:end], lit[0]) {
+				return false
+			}
+			pattern, lit = pattern[end+1:], lit[1:]
+
+		case '{':
+			end := strings.IndexByte(pattern, '}')
+			if end < 0 {
+				return false
+			}
+			rest := pattern[end+1:]
+			for _, alt := range strings.Split(pattern[1:end], ",") {
+				if strings.HasPrefix(lit, alt) && matchSegment(rest, lit[len(alt):]) {
+					return true
+				}
+			}
+			return false
+
+		default:
+			if len(lit) == 0 || pattern[0] != lit[0] {
+				return false
+			}
+			pattern, lit = pattern[1:], lit[1:]
+		}
+	}
+
+	return len(lit) == 0
+}
+
+// matchAddress reports whether the OSC address pattern pattern matches the
+// literal address addr, comparing them '/'-separated segment by segment via
+// matchSegment.
+func matchAddress(pattern, addr string) bool {
+	patSegs := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	addrSegs := strings.Split(strings.TrimPrefix(addr, "/"), "/")
+
+	if len(patSegs) != len(addrSegs) {
+		return false
+	}
+
+	for i, seg := range patSegs {
+		if !matchSegment(seg, addrSegs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchClass reports whether c is matched by the contents of an OSC '[...]'
+// character class, excluding the brackets themselves. A leading '!' negates
+// the class, and 'x-y' pairs are treated as inclusive ranges.
+func matchClass(class string, c byte) bool {
+	negate := strings.HasPrefix(class, "!")
+	if negate {
+		class = class[1:]
+	}
+
+	matched := false
+	for i := 0; i < len(class); i++ {
+		if i+2 < len(class) && class[i+1] == '-' {
+			if class[i] <= c && c <= class[i+2] {
+				matched = true
+			}
+			i += 2
+			continue
+		}
+		if class[i] == c {
+			matched = true
+		}
+	}
+
+	return matched != negate
+}
+
+// validateAddress returns an error if addr is not a valid OSC address
+// pattern: it must start with '/' and must not contain a space or '#'.
+func validateAddress(addr string) error {
+	if !strings.HasPrefix(addr, "/") {
+		return fmt.Errorf("osc: invalid address %q: must start with '/'", addr)
+	}
+	if strings.ContainsAny(addr, " #") {
+		return fmt.Errorf("osc: invalid address %q: must not contain ' ' or '#'", addr)
+	}
+	return nil
+}
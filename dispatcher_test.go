@@ -0,0 +1,88 @@
+package osc
+
+import (
+	"net"
+	"testing"
+)
+
+func TestMatchSegment(t *testing.T) {
+	cases := []struct {
+		pattern, lit string
+		want         bool
+	}{
+		{"foo", "foo", true},
+		{"foo", "bar", false},
+		{"*", "anything", true},
+		{"fo?", "foo", true},
+		{"fo?", "fooo", false},
+		{"[abc]oo", "aoo", true},
+		{"[abc]oo", "doo", false},
+		{"[!abc]oo", "aoo", false},
+		{"[!abc]oo", "doo", true},
+		{"[a-z]oo", "moo", true},
+		{"[a-z]oo", "Moo", false},
+		{"{foo,bar}", "foo", true},
+		{"{foo,bar}", "bar", true},
+		{"{foo,bar}", "baz", false},
+	}
+
+	for _, c := range cases {
+		if got := matchSegment(c.pattern, c.lit); got != c.want {
+			t.Errorf("matchSegment(%q, %q) = %v, want %v", c.pattern, c.lit, got, c.want)
+		}
+	}
+}
+
+func TestPatternDispatcherDispatch(t *testing.T) {
+	d := NewPatternDispatcher()
+
+	var got *Message
+	if err := d.AddMsgHandler("/foo/*", func(msg *Message) { got = msg }); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := NewMessage("/foo/bar")
+	d.Dispatch(msg, &net.UDPAddr{})
+
+	if got != msg {
+		t.Fatal("handler did not run for a matching address")
+	}
+}
+
+func TestPatternDispatcherNoMatch(t *testing.T) {
+	d := NewPatternDispatcher()
+
+	var called bool
+	if err := d.AddMsgHandler("/foo", func(*Message) { called = true }); err != nil {
+		t.Fatal(err)
+	}
+
+	d.Dispatch(NewMessage("/bar"), &net.UDPAddr{})
+
+	if called {
+		t.Fatal("handler ran for a non-matching address")
+	}
+}
+
+func TestPatternDispatcherRemove(t *testing.T) {
+	d := NewPatternDispatcher()
+
+	var called bool
+	if err := d.AddMsgHandler("/foo", func(*Message) { called = true }); err != nil {
+		t.Fatal(err)
+	}
+	d.RemoveMsgHandler("/foo")
+
+	d.Dispatch(NewMessage("/foo"), &net.UDPAddr{})
+
+	if called {
+		t.Fatal("handler should have been removed")
+	}
+}
+
+func TestPatternDispatcherInvalidAddress(t *testing.T) {
+	d := NewPatternDispatcher()
+	if err := d.AddMsgHandler("no-leading-slash", func(*Message) {}); err == nil {
+		t.Fatal("expected an error for an address not starting with '/'")
+	}
+}
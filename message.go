@@ -44,7 +44,7 @@ func (msg *Message) ClearData() {
 // Match returns true, if the OSC address pattern of the OSC Message matches the given
 // address. The match is case sensitive!
 func (msg *Message) Match(addr string) bool {
-	return getRegEx(msg.Address).MatchString(addr)
+	return matchAddress(msg.Address, addr)
 }
 
 // typeTags returns the type tag string.
@@ -56,9 +56,7 @@ func (msg *Message) typeTags() string {
 	var tags strings.Builder
 	_ = tags.WriteByte(',')
 
-	for _, m := range msg.Arguments {
-		tags.WriteByte(getTypeTag(m))
-	}
+	appendTypeTags(&tags, msg.Arguments)
 
 	return tags.String()
 }
@@ -74,23 +72,42 @@ func (msg *Message) String() string {
 	s.WriteString(fmt.Sprintf("%s %s", msg.Address, tags))
 
 	for _, arg := range msg.Arguments {
-		switch argType := arg.(type) {
-		case bool, int32, int64, float32, float64, string:
-			s.WriteString(fmt.Sprintf(" %v", argType))
+		writeArgString(&s, arg)
+	}
 
-		case nil:
-			s.WriteString(" Nil")
+	return s.String()
+}
 
-		case []byte:
-			s.WriteString(fmt.Sprintf(" %s", argType))
+// writeArgString appends a human readable representation of a single OSC
+// argument to s, recursing into array arguments.
+func writeArgString(s *strings.Builder, arg interface{}) {
+	switch argType := arg.(type) {
+	case bool, int32, int64, float32, float64, string, Symbol, Char, RGBA, MIDIMessage:
+		s.WriteString(fmt.Sprintf(" %v", argType))
 
-		case Timetag:
+	case nil:
+		s.WriteString(" Nil")
+
+	case Impulse:
+		s.WriteString(" Impulse")
 
-			s.WriteString(fmt.Sprintf(" %d", Timetag(argType)))
+	case []byte:
+		s.WriteString(fmt.Sprintf(" %s", argType))
+
+	case Timetag:
+
+		s.WriteString(fmt.Sprintf(" %d", Timetag(argType)))
+
+	case []interface{}:
+		s.WriteString(" [")
+		for i, a := range argType {
+			if i > 0 {
+				s.WriteString(",")
+			}
+			writeArgString(s, a)
 		}
+		s.WriteString(" ]")
 	}
-
-	return s.String()
 }
 
 // MarshalBinary serializes the OSC message to a byte buffer. The byte buffer
@@ -108,102 +125,144 @@ func (msg *Message) MarshalBinary() ([]byte, error) {
 	}
 
 	// Type tag string starts with ","
-	lenArgs := len(msg.Arguments)
-	typetags := make([]byte, lenArgs+1)
-	typetags[0] = ','
+	var typetags strings.Builder
+	typetags.WriteByte(',')
 
 	// Process the type tags and collect all arguments
 	payload := new(bytes.Buffer)
 
-	for i, arg := range msg.Arguments {
+	if err := marshalArguments(msg.Arguments, &typetags, payload); err != nil {
+		return nil, err
+	}
+
+	// Write the type tag string to the data buffer
+	if _, err := writePaddedString(typetags.String(), data); err != nil {
+		return nil, err
+	}
+
+	// Write the payload (OSC arguments) to the data buffer
+	if _, err := data.Write(payload.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return data.Bytes(), nil
+}
+
+// marshalArguments writes the type tag character for each of args to tags and
+// its binary representation to payload. A nested []interface{} argument is
+// wrapped in '[' and ']', recursing into marshalArguments for its elements.
+func marshalArguments(args []interface{}, tags *strings.Builder, payload *bytes.Buffer) error {
+	for _, arg := range args {
 		switch t := arg.(type) {
+		case []interface{}:
+			tags.WriteByte('[')
+			if err := marshalArguments(t, tags, payload); err != nil {
+				return err
+			}
+			tags.WriteByte(']')
+
 		case bool:
 			if t {
-				typetags[i+1] = 'T'
+				tags.WriteByte('T')
 				continue
 			}
 
-			typetags[i+1] = 'F'
+			tags.WriteByte('F')
 
 		case nil:
-			typetags[i+1] = 'N'
+			tags.WriteByte('N')
 
 		case int32:
-			typetags[i+1] = 'i'
+			tags.WriteByte('i')
 
-			err = binary.Write(payload, binary.BigEndian, t)
-			if err != nil {
-				return nil, err
+			if err := binary.Write(payload, binary.BigEndian, t); err != nil {
+				return err
 			}
 
 		case float32:
-			typetags[i+1] = 'f'
+			tags.WriteByte('f')
 
-			err := binary.Write(payload, binary.BigEndian, t)
-			if err != nil {
-				return nil, err
+			if err := binary.Write(payload, binary.BigEndian, t); err != nil {
+				return err
 			}
 
 		case string:
-			typetags[i+1] = 's'
+			tags.WriteByte('s')
 
-			_, err = writePaddedString(t, payload)
-			if err != nil {
-				return nil, err
+			if _, err := writePaddedString(t, payload); err != nil {
+				return err
+			}
+
+		case Symbol:
+			tags.WriteByte('S')
+
+			if _, err := writePaddedString(string(t), payload); err != nil {
+				return err
 			}
 
 		case []byte:
-			typetags[i+1] = 'b'
+			tags.WriteByte('b')
 
-			_, err = writeBlob(t, payload)
-			if err != nil {
-				return nil, err
+			if _, err := writeBlob(t, payload); err != nil {
+				return err
 			}
 
 		case int64:
-			typetags[i+1] = 'h'
+			tags.WriteByte('h')
 
-			err = binary.Write(payload, binary.BigEndian, t)
-			if err != nil {
-				return nil, err
+			if err := binary.Write(payload, binary.BigEndian, t); err != nil {
+				return err
 			}
 
 		case float64:
-			typetags[i+1] = 'd'
+			tags.WriteByte('d')
 
-			err = binary.Write(payload, binary.BigEndian, t)
-			if err != nil {
-				return nil, err
+			if err := binary.Write(payload, binary.BigEndian, t); err != nil {
+				return err
 			}
 
 		case Timetag:
-			typetags[i+1] = 't'
+			tags.WriteByte('t')
 
 			b, err := t.MarshalBinary()
 			if err != nil {
-				return nil, err
+				return err
 			}
 
-			_, err = payload.Write(b)
-			if err != nil {
-				return nil, err
+			if _, err := payload.Write(b); err != nil {
+				return err
 			}
-		default:
-			return nil, fmt.Errorf("unsupported type: %T", t)
-		}
-	}
 
-	// Write the type tag string to the data buffer
-	if _, err := writePaddedString(string(typetags), data); err != nil {
-		return nil, err
-	}
+		case RGBA:
+			tags.WriteByte('r')
 
-	// Write the payload (OSC arguments) to the data buffer
-	if _, err := data.Write(payload.Bytes()); err != nil {
-		return nil, err
+			if err := binary.Write(payload, binary.BigEndian, t); err != nil {
+				return err
+			}
+
+		case MIDIMessage:
+			tags.WriteByte('m')
+
+			if err := binary.Write(payload, binary.BigEndian, t); err != nil {
+				return err
+			}
+
+		case Char:
+			tags.WriteByte('c')
+
+			if err := binary.Write(payload, binary.BigEndian, int32(t)); err != nil {
+				return err
+			}
+
+		case Impulse:
+			tags.WriteByte('I')
+
+		default:
+			return fmt.Errorf("unsupported type: %T", t)
+		}
 	}
 
-	return data.Bytes(), nil
+	return nil
 }
 
 // NewMessage returns a new Message. The address parameter is the OSC address.
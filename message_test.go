@@ -0,0 +1,102 @@
+package osc
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+// argEqual compares two decoded OSC arguments, recursing into nested
+// []interface{} array arguments.
+func argEqual(a, b interface{}) bool {
+	aArr, aOk := a.([]interface{})
+	bArr, bOk := b.([]interface{})
+	if aOk != bOk {
+		return false
+	}
+	if !aOk {
+		return a == b
+	}
+	if len(aArr) != len(bArr) {
+		return false
+	}
+	for i := range aArr {
+		if !argEqual(aArr[i], bArr[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMessageMarshalUnmarshalNewTypes(t *testing.T) {
+	want := []interface{}{
+		RGBA{R: 10, G: 20, B: 30, A: 40},
+		MIDIMessage{PortID: 1, Status: 0x90, Data1: 60, Data2: 127},
+		Char('Q'),
+		Symbol("sym"),
+		Impulse{},
+		[]interface{}{int32(1), "nested", []interface{}{int32(2), int32(3)}},
+	}
+
+	msg := NewMessage("/test", want...)
+
+	data, err := msg.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	reader := bufio.NewReader(bytes.NewReader(data))
+	start := 0
+	packet, err := readPacket(reader, &start, len(data), Decoder{})
+	if err != nil {
+		t.Fatalf("readPacket: %v", err)
+	}
+
+	got, ok := packet.(*Message)
+	if !ok {
+		t.Fatalf("got %T, want *Message", packet)
+	}
+
+	if got.Address != msg.Address {
+		t.Errorf("address = %q, want %q", got.Address, msg.Address)
+	}
+
+	if len(got.Arguments) != len(want) {
+		t.Fatalf("arguments = %#v, want %#v", got.Arguments, want)
+	}
+
+	for i := range want {
+		if !argEqual(got.Arguments[i], want[i]) {
+			t.Errorf("argument %d = %#v, want %#v", i, got.Arguments[i], want[i])
+		}
+	}
+}
+
+func TestMessageTypeTags(t *testing.T) {
+	msg := NewMessage("/test", int32(1), RGBA{}, []interface{}{int32(1), Symbol("s")}, Impulse{})
+
+	if got, want := msg.typeTags(), ",ir[iS]I"; got != want {
+		t.Errorf("typeTags() = %q, want %q", got, want)
+	}
+}
+
+func TestMessageString(t *testing.T) {
+	msg := NewMessage("/test", Impulse{}, Symbol("sym"))
+
+	if got, want := msg.String(), "/test ,IS Impulse sym"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestReadArgumentsUnbalancedArray(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := writePaddedString(",]", &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := NewMessage("/test")
+	start := 0
+	if err := readArguments(msg, bufio.NewReader(&buf), &start, Decoder{}); err == nil {
+		t.Fatal("expected an error for an unbalanced ']'")
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
-	"regexp"
+	"io"
 	"strings"
 )
 
@@ -42,7 +42,7 @@ func (f HandlerFunc) HandleMessage(msg *Message) {
 ////
 
 // receivePacket receives an OSC packet from the given reader.
-func readPacket(reader *bufio.Reader, start *int, end int) (Packet, error) {
+func readPacket(reader *bufio.Reader, start *int, end int, dec Decoder) (Packet, error) {
 	// var buf []byte
 	buf, err := reader.Peek(1)
 	if err != nil {
@@ -51,17 +51,17 @@ func readPacket(reader *bufio.Reader, start *int, end int) (Packet, error) {
 
 	switch buf[0] {
 	case '/':
-		return readMessage(reader, start)
+		return readMessage(reader, start, dec)
 
 	case '#':
-		return readBundle(reader, start, end)
+		return readBundle(reader, start, end, dec)
 	}
 
 	return nil, ERROR_INVALID_PACKET
 }
 
 // readBundle reads an Bundle from reader.
-func readBundle(reader *bufio.Reader, start *int, end int) (*Bundle, error) {
+func readBundle(reader *bufio.Reader, start *int, end int, dec Decoder) (*Bundle, error) {
 	// Read the '#bundle' OSC string
 	startTag, n, err := readPaddedString(reader)
 	if err != nil {
@@ -77,6 +77,9 @@ func readBundle(reader *bufio.Reader, start *int, end int) (*Bundle, error) {
 	var timeTag uint64
 	err = binary.Read(reader, binary.BigEndian, &timeTag)
 	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("readBundle: reading timetag: %w", ErrTruncated)
+		}
 		return nil, err
 	}
 
@@ -92,12 +95,19 @@ func readBundle(reader *bufio.Reader, start *int, end int) (*Bundle, error) {
 
 		err = binary.Read(reader, binary.BigEndian, &length)
 		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil, fmt.Errorf("readBundle: reading element length: %w", ErrTruncated)
+			}
 			return nil, err
 		}
 
+		if length < 0 || length%4 != 0 {
+			return nil, fmt.Errorf("readBundle: invalid element length %d: %w", length, ErrTruncated)
+		}
+
 		*start += 4
 
-		p, err := readPacket(reader, start, end)
+		p, err := readPacket(reader, start, end, dec)
 		if err != nil {
 			return nil, err
 		}
@@ -112,7 +122,7 @@ func readBundle(reader *bufio.Reader, start *int, end int) (*Bundle, error) {
 }
 
 // readMessage from `reader`.
-func readMessage(reader *bufio.Reader, start *int) (*Message, error) {
+func readMessage(reader *bufio.Reader, start *int, dec Decoder) (*Message, error) {
 	// First, read the OSC address
 	addr, n, err := readPaddedString(reader)
 	if err != nil {
@@ -123,7 +133,7 @@ func readMessage(reader *bufio.Reader, start *int) (*Message, error) {
 	// Read all arguments
 	msg := NewMessage(addr)
 
-	err = readArguments(msg, reader, start)
+	err = readArguments(msg, reader, start, dec)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +142,7 @@ func readMessage(reader *bufio.Reader, start *int) (*Message, error) {
 }
 
 // readArguments from `reader` and add them to the OSC message `msg`.
-func readArguments(msg *Message, reader *bufio.Reader, start *int) error {
+func readArguments(msg *Message, reader *bufio.Reader, start *int, dec Decoder) error {
 	// Read the type tag string
 	var n int
 	typetags, n, err := readPaddedString(reader)
@@ -153,8 +163,27 @@ func readArguments(msg *Message, reader *bufio.Reader, start *int) error {
 	// Remove ',' from the type tag
 	typetags = typetags[1:]
 
+	// stack of argument lists currently being collected. '[' pushes a new
+	// list and ']' pops it into its parent, so that arrays can nest.
+	stack := [][]interface{}{{}}
+	appendArg := func(v interface{}) {
+		top := len(stack) - 1
+		stack[top] = append(stack[top], v)
+	}
+
 	for _, c := range typetags {
 		switch c {
+		case '[': // start of an array argument
+			stack = append(stack, []interface{}{})
+
+		case ']': // end of an array argument
+			if len(stack) < 2 {
+				return fmt.Errorf("unbalanced ']' in type tag string %s", typetags)
+			}
+			arr := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			appendArg(arr)
+
 		case 'i': // int32
 			var i int32
 
@@ -164,7 +193,7 @@ func readArguments(msg *Message, reader *bufio.Reader, start *int) error {
 			}
 
 			*start += 4
-			msg.Append(i)
+			appendArg(i)
 
 		case 'h': // int64
 			var i int64
@@ -173,7 +202,7 @@ func readArguments(msg *Message, reader *bufio.Reader, start *int) error {
 				return err
 			}
 			*start += 8
-			msg.Append(i)
+			appendArg(i)
 
 		case 'f': // float32
 			var f float32
@@ -182,7 +211,7 @@ func readArguments(msg *Message, reader *bufio.Reader, start *int) error {
 				return err
 			}
 			*start += 4
-			msg.Append(f)
+			appendArg(f)
 
 		case 'd': // float64/double
 			var d float64
@@ -191,7 +220,7 @@ func readArguments(msg *Message, reader *bufio.Reader, start *int) error {
 				return err
 			}
 			*start += 8
-			msg.Append(d)
+			appendArg(d)
 
 		case 's': // string
 			// TODO: fix reading string value
@@ -202,43 +231,89 @@ func readArguments(msg *Message, reader *bufio.Reader, start *int) error {
 			}
 			lenStr := len(s)
 			*start += lenStr + padBytesNeeded(lenStr)
-			msg.Append(s)
+			appendArg(s)
+
+		case 'S': // symbol (alternate string)
+			var s string
+			s, _, err = readPaddedString(reader)
+			if err != nil {
+				return err
+			}
+			lenStr := len(s)
+			*start += lenStr + padBytesNeeded(lenStr)
+			appendArg(Symbol(s))
 
 		case 'b': // blob
 			var buf []byte
 			var n int
-			buf, n, err = readBlob(reader)
+			buf, n, err = readBlob(reader, dec)
 			if err != nil {
 				return err
 			}
 			*start += n
-			msg.Append(buf)
+			appendArg(buf)
 
 		case 't': // OSC time tag
 			var tt uint64
 
 			err = binary.Read(reader, binary.BigEndian, &tt)
 			if err != nil {
-				return nil
+				return err
 			}
 
 			*start += 8
-			msg.Append(*NewTimetagFromTimetag(tt))
+			appendArg(*NewTimetagFromTimetag(tt))
+
+		case 'r': // RGBA color
+			var color RGBA
+			err = binary.Read(reader, binary.BigEndian, &color)
+			if err != nil {
+				return err
+			}
+			*start += 4
+			appendArg(color)
+
+		case 'm': // MIDI message
+			var midi MIDIMessage
+			err = binary.Read(reader, binary.BigEndian, &midi)
+			if err != nil {
+				return err
+			}
+			*start += 4
+			appendArg(midi)
+
+		case 'c': // ASCII character, sent as a big-endian int32
+			var i int32
+			err = binary.Read(reader, binary.BigEndian, &i)
+			if err != nil {
+				return err
+			}
+			*start += 4
+			appendArg(Char(i))
 
 		case 'N': // nil
-			msg.Append(nil)
+			appendArg(nil)
 
 		case 'T': // true
-			msg.Append(true)
+			appendArg(true)
 
 		case 'F': // false
-			msg.Append(false)
+			appendArg(false)
+
+		case 'I': // impulse / infinitum
+			appendArg(Impulse{})
 
 		default:
 			return fmt.Errorf("unsupported type tag: %c", c)
 		}
 	}
 
+	if len(stack) != 1 {
+		return fmt.Errorf("unbalanced '[' in type tag string %s", typetags)
+	}
+
+	msg.Arguments = append(msg.Arguments, stack[0]...)
+
 	return nil
 }
 
@@ -246,23 +321,36 @@ func readArguments(msg *Message, reader *bufio.Reader, start *int) error {
 // De/Encoding functions
 ////
 
-// readBlob reads an OSC blob from the blob byte array. Padding bytes are
-// removed from the reader and not returned.
-func readBlob(reader *bufio.Reader) ([]byte, int, error) {
+// readBlob reads an OSC blob from reader. Fixed-length reads use io.ReadFull
+// so a blob delivered across several underlying reads (as happens on a slow
+// stream, unlike a single buffered UDP datagram) is still read in full.
+// Padding bytes are removed from the reader and not returned.
+func readBlob(reader *bufio.Reader, dec Decoder) ([]byte, int, error) {
 	// First, get the length
 	var blobLen int32
 	if err := binary.Read(reader, binary.BigEndian, &blobLen); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, 0, fmt.Errorf("readBlob: reading length: %w", ErrTruncated)
+		}
 		return nil, 0, err
 	}
-	n := 4 + int(blobLen)
 
-	if blobLen < 1 || blobLen > int32(reader.Buffered()) {
-		return nil, 0, fmt.Errorf("readBlob: invalid blob length %d", blobLen)
+	if blobLen < 0 {
+		return nil, 0, fmt.Errorf("readBlob: invalid blob length %d: %w", blobLen, ErrTruncated)
+	}
+
+	if blobLen > dec.maxBlobSize() {
+		return nil, 0, fmt.Errorf("readBlob: blob length %d exceeds maximum %d: %w", blobLen, dec.maxBlobSize(), ErrOversizedPacket)
 	}
 
+	n := 4 + int(blobLen)
+
 	// Read the data
 	blob := make([]byte, blobLen)
-	if _, err := reader.Read(blob); err != nil {
+	if _, err := io.ReadFull(reader, blob); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, 0, fmt.Errorf("readBlob: reading body: %w", ErrTruncated)
+		}
 		return nil, 0, err
 	}
 
@@ -271,7 +359,10 @@ func readBlob(reader *bufio.Reader) ([]byte, int, error) {
 	if numPadBytes > 0 {
 		n += numPadBytes
 		dummy := make([]byte, numPadBytes)
-		if _, err := reader.Read(dummy); err != nil {
+		if _, err := io.ReadFull(reader, dummy); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil, 0, fmt.Errorf("readBlob: reading padding: %w", ErrTruncated)
+			}
 			return nil, 0, err
 		}
 	}
@@ -315,6 +406,9 @@ func readPaddedString(reader *bufio.Reader) (string, int, error) {
 	// Read the string from the reader
 	str, err := reader.ReadString(0)
 	if err != nil {
+		if err == io.EOF {
+			return "", 0, fmt.Errorf("readPaddedString: %w", ErrTruncated)
+		}
 		return "", 0, err
 	}
 	lenStr := len(str)
@@ -325,7 +419,10 @@ func readPaddedString(reader *bufio.Reader) (string, int, error) {
 	if padLen > 0 {
 		n += padLen
 		padBytes := make([]byte, padLen)
-		if _, err = reader.Read(padBytes); err != nil {
+		if _, err = io.ReadFull(reader, padBytes); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return "", 0, fmt.Errorf("readPaddedString: reading padding: %w", ErrTruncated)
+			}
 			return "", 0, err
 		}
 	}
@@ -377,37 +474,6 @@ func padBytesNeeded(elementLen int) int {
 // Utility and helper functions
 ////
 
-// addressExists returns true if the OSC address `addr` is found in `handlers`.
-func addressExists(addr string, handlers map[string]Handler) bool {
-	for h := range handlers {
-		if h == addr {
-			return true
-		}
-	}
-	return false
-}
-
-// getRegEx compiles and returns a regular expression object for the given
-// address `pattern`.
-func getRegEx(pattern string) *regexp.Regexp {
-	for _, trs := range []struct {
-		old, new string
-	}{
-		{".", `\.`}, // Escape all '.' in the pattern
-		{"(", `\(`}, // Escape all '(' in the pattern
-		{")", `\)`}, // Escape all ')' in the pattern
-		{"*", ".*"}, // Replace a '*' with '.*' that matches zero or more chars
-		{"{", "("},  // Change a '{' to '('
-		{",", "|"},  // Change a ',' to '|'
-		{"}", ")"},  // Change a '}' to ')'
-		{"?", "."},  // Change a '?' to '.'
-	} {
-		pattern = strings.Replace(pattern, trs.old, trs.new, -1)
-	}
-
-	return regexp.MustCompile(pattern)
-}
-
 // getTypeTag returns the OSC type tag for the given argument.
 func getTypeTag(arg interface{}) byte {
 	switch t := arg.(type) {
@@ -424,6 +490,8 @@ func getTypeTag(arg interface{}) byte {
 		return 'f'
 	case string:
 		return 's'
+	case Symbol:
+		return 'S'
 	case []byte:
 		return 'b'
 	case int64:
@@ -432,7 +500,30 @@ func getTypeTag(arg interface{}) byte {
 		return 'd'
 	case Timetag:
 		return 't'
+	case RGBA:
+		return 'r'
+	case MIDIMessage:
+		return 'm'
+	case Char:
+		return 'c'
+	case Impulse:
+		return 'I'
 	default:
 		return '\xff'
 	}
 }
+
+// appendTypeTags writes the type tag character for each of args to tags,
+// wrapping nested []interface{} arguments in '[' and ']' per the OSC array
+// convention.
+func appendTypeTags(tags *strings.Builder, args []interface{}) {
+	for _, arg := range args {
+		if nested, ok := arg.([]interface{}); ok {
+			tags.WriteByte('[')
+			appendTypeTags(tags, nested)
+			tags.WriteByte(']')
+			continue
+		}
+		tags.WriteByte(getTypeTag(arg))
+	}
+}
@@ -0,0 +1,191 @@
+package osc
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// immediateTime is the time.Time produced by decoding the special OSC
+// "dispatch immediately" timetag (seconds=0, fraction=1). An outermost bundle
+// carrying this timetag is dispatched synchronously rather than scheduled; a
+// nested bundle carrying it inherits its parent's effective time instead.
+var immediateTime = timetagToTime(1)
+
+// Clock is the time seam used by Scheduler implementations, so that tests can
+// drive scheduling deterministically instead of depending on wall-clock time.
+// The zero value is not usable; use defaultClock or a test-provided Clock.
+type Clock struct {
+	Now       func() time.Time
+	AfterFunc func(d time.Duration, f func()) *time.Timer
+}
+
+// defaultClock returns the Clock backed by the time package.
+func defaultClock() Clock {
+	return Clock{Now: time.Now, AfterFunc: time.AfterFunc}
+}
+
+// Scheduler delays the delivery of scheduled funcs until a given point in
+// time, as required for timetag-aware bundle dispatch.
+type Scheduler interface {
+	// Schedule arranges for fn to run at (or soon after) at. If at is not
+	// after the scheduler's current time, fn runs synchronously on the
+	// calling goroutine.
+	Schedule(at time.Time, fn func())
+
+	// Close stops the scheduler. Funcs scheduled but not yet due are
+	// discarded.
+	Close() error
+}
+
+// schedulerItem is an entry in a HeapScheduler's priority queue.
+type schedulerItem struct {
+	at time.Time
+	fn func()
+}
+
+// timeHeap implements container/heap.Interface, ordering items by at.
+type timeHeap []*schedulerItem
+
+func (h timeHeap) Len() int            { return len(h) }
+func (h timeHeap) Less(i, j int) bool  { return h[i].at.Before(h[j].at) }
+func (h timeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *timeHeap) Push(x interface{}) { *h = append(*h, x.(*schedulerItem)) }
+func (h *timeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// HeapScheduler is the default Scheduler: a min-heap of pending funcs keyed by
+// absolute time, with a single timer armed for the earliest one.
+type HeapScheduler struct {
+	clock Clock
+
+	mu     sync.Mutex
+	items  timeHeap
+	timer  *time.Timer
+	closed bool
+}
+
+// NewHeapScheduler returns a HeapScheduler driven by the real system clock.
+func NewHeapScheduler() *HeapScheduler {
+	return NewHeapSchedulerWithClock(defaultClock())
+}
+
+// NewHeapSchedulerWithClock returns a HeapScheduler driven by clock, so that
+// tests can control when scheduled funcs fire.
+func NewHeapSchedulerWithClock(clock Clock) *HeapScheduler {
+	return &HeapScheduler{clock: clock}
+}
+
+// Schedule implements Scheduler.
+func (s *HeapScheduler) Schedule(at time.Time, fn func()) {
+	if !at.After(s.clock.Now()) {
+		fn()
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	heap.Push(&s.items, &schedulerItem{at: at, fn: fn})
+	s.rearm()
+}
+
+// rearm resets the timer to fire when the earliest pending item is due.
+// Callers must hold s.mu.
+func (s *HeapScheduler) rearm() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+
+	if len(s.items) == 0 {
+		return
+	}
+
+	wait := s.items[0].at.Sub(s.clock.Now())
+	if wait < 0 {
+		wait = 0
+	}
+
+	s.timer = s.clock.AfterFunc(wait, s.fireDue)
+}
+
+// fireDue runs every item whose time has come and rearms for the next one.
+func (s *HeapScheduler) fireDue() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+
+	now := s.clock.Now()
+
+	var due []*schedulerItem
+	for len(s.items) > 0 && !s.items[0].at.After(now) {
+		due = append(due, heap.Pop(&s.items).(*schedulerItem))
+	}
+
+	s.rearm()
+	s.mu.Unlock()
+
+	for _, item := range due {
+		item.fn()
+	}
+}
+
+// Close implements Scheduler. Close discards any items still pending in
+// addition to stopping the timer, since Stop does not guarantee that an
+// already-fired AfterFunc callback is prevented from running concurrently
+// with Close.
+func (s *HeapScheduler) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.items = nil
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+
+	return nil
+}
+
+// DispatchBundle walks b, calling dispatch for every contained Message.
+// Messages whose effective timetag has already passed, which includes an
+// outermost bundle carrying the special "immediate" sentinel, are dispatched
+// synchronously; everything else is handed to sched to run once its timetag
+// is reached. A nested bundle carrying the immediate sentinel inherits its
+// parent's effective timetag instead of firing immediately on its own,
+// matching the OSC 1.0 semantics for bundles-within-bundles.
+func DispatchBundle(b *Bundle, sched Scheduler, dispatch func(*Message)) {
+	dispatchBundleAt(b, b.Timetag.Time(), sched, dispatch)
+}
+
+// dispatchBundleAt walks b using inherited as the effective time for any
+// element (message or nested bundle) whose own timetag is the immediate
+// sentinel.
+func dispatchBundleAt(b *Bundle, inherited time.Time, sched Scheduler, dispatch func(*Message)) {
+	effective := b.Timetag.Time()
+	if effective.Equal(immediateTime) {
+		effective = inherited
+	}
+
+	for _, msg := range b.Messages {
+		m := msg
+		sched.Schedule(effective, func() { dispatch(m) })
+	}
+
+	for _, nested := range b.Bundles {
+		dispatchBundleAt(nested, effective, sched, dispatch)
+	}
+}
@@ -0,0 +1,134 @@
+package osc
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose AfterFunc never actually waits; tests drive it
+// forward explicitly with advance, so scheduling can be verified
+// deterministically instead of depending on wall-clock time.
+type fakeClock struct {
+	now     time.Time
+	pending []func()
+}
+
+func (c *fakeClock) clock() Clock {
+	return Clock{
+		Now: func() time.Time { return c.now },
+		AfterFunc: func(d time.Duration, f func()) *time.Timer {
+			c.pending = append(c.pending, f)
+			return time.NewTimer(time.Hour)
+		},
+	}
+}
+
+// advance moves the fake clock forward by d and runs every func armed via
+// AfterFunc since the last advance.
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+
+	pending := c.pending
+	c.pending = nil
+	for _, fn := range pending {
+		fn()
+	}
+}
+
+func TestHeapSchedulerRunsPastTimesImmediately(t *testing.T) {
+	fc := &fakeClock{now: time.Unix(1000, 0)}
+	sched := NewHeapSchedulerWithClock(fc.clock())
+	defer sched.Close()
+
+	var ran bool
+	sched.Schedule(fc.now, func() { ran = true })
+
+	if !ran {
+		t.Fatal("expected synchronous dispatch for a non-future time")
+	}
+}
+
+func TestHeapSchedulerDelaysFutureTimes(t *testing.T) {
+	fc := &fakeClock{now: time.Unix(1000, 0)}
+	sched := NewHeapSchedulerWithClock(fc.clock())
+	defer sched.Close()
+
+	var ran bool
+	sched.Schedule(fc.now.Add(5*time.Second), func() { ran = true })
+
+	if ran {
+		t.Fatal("expected a future schedule to not run synchronously")
+	}
+
+	fc.advance(5 * time.Second)
+
+	if !ran {
+		t.Fatal("expected the scheduled func to run once its time arrived")
+	}
+}
+
+func TestHeapSchedulerOrdersByTime(t *testing.T) {
+	fc := &fakeClock{now: time.Unix(1000, 0)}
+	sched := NewHeapSchedulerWithClock(fc.clock())
+	defer sched.Close()
+
+	var order []int
+	sched.Schedule(fc.now.Add(10*time.Second), func() { order = append(order, 2) })
+	sched.Schedule(fc.now.Add(5*time.Second), func() { order = append(order, 1) })
+
+	fc.advance(10 * time.Second)
+
+	if len(order) != 2 || order[0] != 1 || order[1] != 2 {
+		t.Fatalf("order = %v, want [1 2]", order)
+	}
+}
+
+func TestHeapSchedulerCloseDiscardsPending(t *testing.T) {
+	fc := &fakeClock{now: time.Unix(1000, 0)}
+	sched := NewHeapSchedulerWithClock(fc.clock())
+
+	var ran bool
+	sched.Schedule(fc.now.Add(5*time.Second), func() { ran = true })
+
+	if err := sched.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	fc.advance(5 * time.Second)
+
+	if ran {
+		t.Fatal("expected Close to discard pending scheduled funcs")
+	}
+}
+
+func TestDispatchBundleNestedInheritsParentTimetag(t *testing.T) {
+	fc := &fakeClock{now: time.Unix(1000, 0)}
+	sched := NewHeapSchedulerWithClock(fc.clock())
+	defer sched.Close()
+
+	future := fc.now.Add(10 * time.Second)
+
+	outer := NewBundle(future)
+	inner := NewBundle(immediateTime) // should inherit outer's effective time
+
+	msg := NewMessage("/inherited")
+	if err := inner.Append(msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := outer.Append(inner); err != nil {
+		t.Fatal(err)
+	}
+
+	var got *Message
+	DispatchBundle(outer, sched, func(m *Message) { got = m })
+
+	if got != nil {
+		t.Fatal("message should not dispatch before the inherited timetag is reached")
+	}
+
+	fc.advance(10 * time.Second)
+
+	if got != msg {
+		t.Fatalf("got %v, want the message to dispatch once the inherited timetag arrived", got)
+	}
+}
@@ -0,0 +1,112 @@
+package osc
+
+import (
+	"bytes"
+	"log"
+	"net"
+)
+
+// Server listens for OSC packets on a UDP connection and routes them through
+// a Dispatcher, honoring bundle timetags via a Scheduler.
+type Server struct {
+	addr       string
+	dispatcher Dispatcher
+	scheduler  Scheduler
+	decoder    Decoder
+	onError    func(addr net.Addr, err error)
+}
+
+// ServerOption configures optional Server behavior.
+type ServerOption func(*Server)
+
+// WithDispatcher sets the Dispatcher used to route incoming packets. The
+// default is a *PatternDispatcher.
+func WithDispatcher(d Dispatcher) ServerOption {
+	return func(s *Server) { s.dispatcher = d }
+}
+
+// WithScheduler sets the Scheduler used to delay bundled messages until their
+// timetag is reached. The default is a *HeapScheduler.
+func WithScheduler(sched Scheduler) ServerOption {
+	return func(s *Server) { s.scheduler = sched }
+}
+
+// WithDecoder sets the Decoder used to bound and harden incoming packet
+// parsing. The default is a zero-value Decoder.
+func WithDecoder(dec Decoder) ServerOption {
+	return func(s *Server) { s.decoder = dec }
+}
+
+// WithErrorHandler sets the func called when a received datagram fails to
+// decode as an OSC packet, instead of the default which logs it via the
+// standard log package. A malformed or hostile datagram from one sender must
+// never stop the Server from serving everyone else, so the handler's return
+// value (if any) is ignored; to stop the Server, close its connection from
+// another goroutine.
+func WithErrorHandler(onError func(addr net.Addr, err error)) ServerOption {
+	return func(s *Server) { s.onError = onError }
+}
+
+// NewServer returns a new Server that will listen on addr.
+func NewServer(addr string, opts ...ServerOption) *Server {
+	s := &Server{
+		addr:       addr,
+		dispatcher: NewPatternDispatcher(),
+		scheduler:  NewHeapScheduler(),
+		onError: func(addr net.Addr, err error) {
+			log.Printf("osc: dropping malformed packet from %s: %v", addr, err)
+		},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// ListenAndServe listens on the Server's address and dispatches incoming OSC
+// packets until reading from the connection itself fails (e.g. it is
+// closed). A datagram that fails to decode is reported to the Server's error
+// handler and otherwise ignored, rather than stopping the listener: one
+// sender's malformed or hostile packet must not deny service to everyone
+// else.
+func (s *Server) ListenAndServe() error {
+	conn, err := net.ListenPacket("udp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	buf := make([]byte, 65535)
+
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		packet, err := s.decoder.ReadPacket(bytes.NewReader(buf[:n]), n)
+		if err != nil {
+			s.onError(addr, err)
+			continue
+		}
+
+		s.serve(packet, addr)
+	}
+}
+
+// serve hands packet to the Server's Dispatcher, first unrolling any Bundle
+// through the Scheduler so its messages are delivered once their timetag is
+// reached.
+func (s *Server) serve(packet Packet, addr net.Addr) {
+	bundle, ok := packet.(*Bundle)
+	if !ok {
+		s.dispatcher.Dispatch(packet, addr)
+		return
+	}
+
+	DispatchBundle(bundle, s.scheduler, func(msg *Message) {
+		s.dispatcher.Dispatch(msg, addr)
+	})
+}
@@ -0,0 +1,81 @@
+package osc
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// SLIP framing constants as defined by RFC 1055.
+const (
+	slipEnd    byte = 0xC0
+	slipEsc    byte = 0xDB
+	slipEscEnd byte = 0xDC
+	slipEscEsc byte = 0xDD
+)
+
+// slipEncode wraps data in SLIP framing: every END and ESC byte already
+// present in data is escaped, and the frame is terminated with a trailing END
+// byte.
+func slipEncode(data []byte) []byte {
+	out := make([]byte, 0, len(data)+2)
+
+	for _, b := range data {
+		switch b {
+		case slipEnd:
+			out = append(out, slipEsc, slipEscEnd)
+		case slipEsc:
+			out = append(out, slipEsc, slipEscEsc)
+		default:
+			out = append(out, b)
+		}
+	}
+
+	return append(out, slipEnd)
+}
+
+// slipDecode reads a single SLIP frame from reader and returns its decoded
+// payload. A stray END byte with no preceding data (used by some encoders to
+// flush a possibly corrupt previous frame) is skipped rather than returned as
+// an empty packet. The accumulated frame is bounded by dec's MaxPacketSize,
+// so a connection that streams non-END bytes without ever terminating a
+// frame is rejected instead of growing the buffer without limit.
+func slipDecode(reader *bufio.Reader, dec Decoder) ([]byte, error) {
+	var out []byte
+
+	for {
+		if int32(len(out)) > dec.maxPacketSize() {
+			return nil, fmt.Errorf("osc: SLIP frame exceeds maximum size %d: %w", dec.maxPacketSize(), ErrOversizedPacket)
+		}
+
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch b {
+		case slipEnd:
+			if len(out) == 0 {
+				continue
+			}
+			return out, nil
+
+		case slipEsc:
+			esc, err := reader.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+
+			switch esc {
+			case slipEscEnd:
+				out = append(out, slipEnd)
+			case slipEscEsc:
+				out = append(out, slipEsc)
+			default:
+				return nil, fmt.Errorf("osc: invalid SLIP escape sequence 0x%x", esc)
+			}
+
+		default:
+			out = append(out, b)
+		}
+	}
+}
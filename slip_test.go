@@ -0,0 +1,59 @@
+package osc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSlipEncodeDecodeRoundTrip(t *testing.T) {
+	want := []byte{0x00, slipEnd, 0x01, slipEsc, 0x02}
+
+	encoded := slipEncode(want)
+
+	got, err := slipDecode(bufio.NewReader(bytes.NewReader(encoded)), Decoder{})
+	if err != nil {
+		t.Fatalf("slipDecode: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("slipDecode() = %v, want %v", got, want)
+	}
+}
+
+func TestSlipDecodeSkipsLeadingEnd(t *testing.T) {
+	want := []byte{1, 2, 3}
+
+	var buf bytes.Buffer
+	buf.WriteByte(slipEnd) // stray flush byte before the real frame
+	buf.Write(slipEncode(want))
+
+	got, err := slipDecode(bufio.NewReader(&buf), Decoder{})
+	if err != nil {
+		t.Fatalf("slipDecode: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("slipDecode() = %v, want %v", got, want)
+	}
+}
+
+func TestSlipDecodeInvalidEscape(t *testing.T) {
+	buf := bytes.NewReader([]byte{slipEsc, 0x01, slipEnd})
+
+	if _, err := slipDecode(bufio.NewReader(buf), Decoder{}); err == nil {
+		t.Fatal("expected an error for an invalid SLIP escape sequence")
+	}
+}
+
+func TestSlipDecodeBoundsFrameSize(t *testing.T) {
+	dec := Decoder{MaxPacketSize: 4}
+
+	data := bytes.Repeat([]byte{0x01}, 1024) // never hits a slipEnd byte
+
+	_, err := slipDecode(bufio.NewReader(bytes.NewReader(data)), dec)
+	if !errors.Is(err, ErrOversizedPacket) {
+		t.Fatalf("err = %v, want ErrOversizedPacket", err)
+	}
+}
@@ -0,0 +1,185 @@
+package osc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+// FramingMode selects how Packets are delimited on an OSC stream connection,
+// per the two framing options defined by the OSC 1.1 stream specification.
+type FramingMode int
+
+const (
+	// LengthPrefixFraming prefixes every packet with a 4-byte big-endian
+	// length, as used by liblo and Pure Data.
+	LengthPrefixFraming FramingMode = iota
+
+	// SLIPFraming delimits every packet with SLIP framing (RFC 1055).
+	SLIPFraming
+)
+
+// ReadPacket parses a single OSC packet of exactly length bytes from r using
+// a zero-value Decoder. length is supplied explicitly by the caller (on a
+// stream, by the framing layer: a length-prefix header or a decoded SLIP
+// frame) instead of being bounded by how much data a *bufio.Reader has
+// buffered.
+func ReadPacket(r io.Reader, length int) (Packet, error) {
+	return Decoder{}.ReadPacket(r, length)
+}
+
+// StreamConnOption configures optional StreamConn behavior.
+type StreamConnOption func(*StreamConn)
+
+// WithStreamDecoder sets the Decoder used to bound and harden the connection's
+// packet parsing. The default is a zero-value Decoder.
+func WithStreamDecoder(dec Decoder) StreamConnOption {
+	return func(c *StreamConn) { c.decoder = dec }
+}
+
+// StreamConn is an OSC connection over a stream-oriented transport (TCP),
+// framing packets according to its FramingMode.
+type StreamConn struct {
+	conn    net.Conn
+	mode    FramingMode
+	reader  *bufio.Reader
+	decoder Decoder
+}
+
+// NewStreamConn wraps conn as an OSC StreamConn using the given framing mode.
+func NewStreamConn(conn net.Conn, mode FramingMode, opts ...StreamConnOption) *StreamConn {
+	c := &StreamConn{
+		conn:   conn,
+		mode:   mode,
+		reader: bufio.NewReader(conn),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Close closes the underlying connection.
+func (c *StreamConn) Close() error {
+	return c.conn.Close()
+}
+
+// ReadPacket reads and parses the next framed OSC packet from the connection,
+// blocking until one full packet has arrived.
+func (c *StreamConn) ReadPacket() (Packet, error) {
+	if c.mode == SLIPFraming {
+		frame, err := slipDecode(c.reader, c.decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.decoder.ReadPacket(bytes.NewReader(frame), len(frame))
+	}
+
+	var length int32
+	if err := binary.Read(c.reader, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+
+	if length < 0 {
+		return nil, fmt.Errorf("osc: invalid stream packet length %d: %w", length, ErrTruncated)
+	}
+
+	if length > c.decoder.maxPacketSize() {
+		return nil, fmt.Errorf("osc: stream packet length %d exceeds maximum %d: %w", length, c.decoder.maxPacketSize(), ErrOversizedPacket)
+	}
+
+	// Read exactly the declared length into its own buffer, the same
+	// isolation the SLIP branch gets from its decoded frame, so a length
+	// header that doesn't match the marshaled content affects this packet
+	// alone (it fails to parse, or any bytes past the parsed packet are
+	// ignored) instead of desyncing every packet after it on the connection.
+	frame := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, frame); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("osc: truncated stream packet: %w", ErrTruncated)
+		}
+		return nil, err
+	}
+
+	return c.decoder.ReadPacket(bytes.NewReader(frame), int(length))
+}
+
+// WritePacket marshals p and writes it to the connection, framed according to
+// the connection's FramingMode.
+func (c *StreamConn) WritePacket(p Packet) error {
+	data, err := p.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	if c.mode == SLIPFraming {
+		_, err = c.conn.Write(slipEncode(data))
+		return err
+	}
+
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(data)))
+
+	if _, err := c.conn.Write(header[:]); err != nil {
+		return err
+	}
+
+	_, err = c.conn.Write(data)
+
+	return err
+}
+
+// StreamListener accepts incoming OSC StreamConns over TCP.
+type StreamListener struct {
+	ln   net.Listener
+	mode FramingMode
+	opts []StreamConnOption
+}
+
+// ListenTCP announces an OSC stream listener on addr using the given framing
+// mode. Any opts are applied to every StreamConn returned from Accept.
+func ListenTCP(addr string, mode FramingMode, opts ...StreamConnOption) (*StreamListener, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamListener{ln: ln, mode: mode, opts: opts}, nil
+}
+
+// Accept waits for and returns the next incoming OSC StreamConn.
+func (l *StreamListener) Accept() (*StreamConn, error) {
+	conn, err := l.ln.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStreamConn(conn, l.mode, l.opts...), nil
+}
+
+// Close closes the listener.
+func (l *StreamListener) Close() error {
+	return l.ln.Close()
+}
+
+// Addr returns the listener's network address.
+func (l *StreamListener) Addr() net.Addr {
+	return l.ln.Addr()
+}
+
+// DialTCP connects to addr and returns an OSC StreamConn using the given
+// framing mode.
+func DialTCP(addr string, mode FramingMode, opts ...StreamConnOption) (*StreamConn, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStreamConn(conn, mode, opts...), nil
+}
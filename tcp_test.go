@@ -0,0 +1,140 @@
+package osc
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func dialTCPPair(t *testing.T, mode FramingMode) (*StreamConn, *StreamConn) {
+	t.Helper()
+
+	ln, err := ListenTCP("127.0.0.1:0", mode)
+	if err != nil {
+		t.Fatalf("ListenTCP: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	accepted := make(chan *StreamConn, 1)
+	acceptErr := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			acceptErr <- err
+			return
+		}
+		accepted <- conn
+	}()
+
+	client, err := DialTCP(ln.Addr().String(), mode)
+	if err != nil {
+		t.Fatalf("DialTCP: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	select {
+	case err := <-acceptErr:
+		t.Fatalf("Accept: %v", err)
+		return nil, nil
+	case server := <-accepted:
+		t.Cleanup(func() { server.Close() })
+		return client, server
+	}
+}
+
+func testStreamConnRoundTrip(t *testing.T, mode FramingMode) {
+	client, server := dialTCPPair(t, mode)
+
+	want := NewMessage("/ping", int32(42))
+	if err := client.WritePacket(want); err != nil {
+		t.Fatalf("WritePacket: %v", err)
+	}
+
+	got, err := server.ReadPacket()
+	if err != nil {
+		t.Fatalf("ReadPacket: %v", err)
+	}
+
+	msg, ok := got.(*Message)
+	if !ok {
+		t.Fatalf("got %T, want *Message", got)
+	}
+
+	if msg.Address != want.Address {
+		t.Errorf("Address = %q, want %q", msg.Address, want.Address)
+	}
+	if !argEqual(msg.Arguments[0], want.Arguments[0]) {
+		t.Errorf("Arguments[0] = %#v, want %#v", msg.Arguments[0], want.Arguments[0])
+	}
+}
+
+func TestStreamConnRoundTripLengthPrefix(t *testing.T) {
+	testStreamConnRoundTrip(t, LengthPrefixFraming)
+}
+
+func TestStreamConnRoundTripSLIP(t *testing.T) {
+	testStreamConnRoundTrip(t, SLIPFraming)
+}
+
+func TestStreamConnReadPacketDoesNotDesyncOnMismatchedLength(t *testing.T) {
+	client, server := dialTCPPair(t, LengthPrefixFraming)
+
+	good := NewMessage("/ok")
+	data, err := good.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	// Write a length header that over-declares the frame by 4 bytes, the
+	// malformed-peer scenario from the stream-framing hardening request.
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(data)+4))
+	if _, err := client.conn.Write(header[:]); err != nil {
+		t.Fatalf("write header: %v", err)
+	}
+	if _, err := client.conn.Write(data); err != nil {
+		t.Fatalf("write body: %v", err)
+	}
+	if _, err := client.conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		t.Fatalf("write padding: %v", err)
+	}
+
+	// The over-declared length is read as one isolated frame (the marshaled
+	// message plus its 4 bytes of bogus padding); whether that frame parses
+	// or errors, it must consume exactly its declared length from the
+	// connection and nothing more.
+	server.ReadPacket()
+
+	// A subsequent, correctly framed packet on the same connection must
+	// still parse: the bad frame must not have desynced the stream.
+	want := NewMessage("/ping")
+	if err := client.WritePacket(want); err != nil {
+		t.Fatalf("WritePacket: %v", err)
+	}
+
+	got, err := server.ReadPacket()
+	if err != nil {
+		t.Fatalf("ReadPacket after bad length: %v", err)
+	}
+
+	msg, ok := got.(*Message)
+	if !ok || msg.Address != want.Address {
+		t.Fatalf("got %#v, want a *Message for %q", got, want.Address)
+	}
+}
+
+func TestStreamConnReadPacketRejectsOversizedLength(t *testing.T) {
+	client, server := dialTCPPair(t, LengthPrefixFraming)
+	server.decoder = Decoder{MaxPacketSize: 4}
+
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], 1<<20)
+	if _, err := client.conn.Write(header[:]); err != nil {
+		t.Fatalf("write header: %v", err)
+	}
+
+	_, err := server.ReadPacket()
+	if !errors.Is(err, ErrOversizedPacket) {
+		t.Fatalf("err = %v, want ErrOversizedPacket", err)
+	}
+}
@@ -0,0 +1,28 @@
+package osc
+
+// RGBA represents an OSC RGBA color argument (type tag 'r'): four bytes for
+// the red, green, blue and alpha channels.
+type RGBA struct {
+	R, G, B, A byte
+}
+
+// MIDIMessage represents an OSC MIDI message argument (type tag 'm'): a port
+// id byte followed by the standard 3-byte MIDI status/data1/data2 triplet.
+type MIDIMessage struct {
+	PortID, Status, Data1, Data2 byte
+}
+
+// Char represents an OSC ASCII character argument (type tag 'c'), which is
+// transmitted as a big-endian int32. It is a distinct type rather than a bare
+// rune so that it can be told apart from an 'i' int32 argument when encoding.
+type Char rune
+
+// Symbol represents an OSC alternate string argument (type tag 'S'). It is
+// encoded exactly like a regular string but kept as a distinct type so that a
+// round trip through MarshalBinary preserves the 'S' tag instead of emitting
+// 's'.
+type Symbol string
+
+// Impulse represents the OSC infinitum/impulse argument (type tag 'I'). It
+// carries no payload; its mere presence in the type tag string is the value.
+type Impulse struct{}